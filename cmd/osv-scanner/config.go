@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+// ConfigCommand returns the `osv-scanner config` command group. Register it
+// alongside the other top-level commands in the app's Commands slice.
+func ConfigCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "manage osv-scanner.toml config files",
+		Subcommands: []*cli.Command{
+			configValidateCommand(),
+			configAuditCommand(),
+		},
+	}
+}
+
+func configValidateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "validate an osv-scanner.toml config file against the schema and hygiene checks",
+		ArgsUsage: "<path to osv-scanner.toml>",
+		Action: func(ctx *cli.Context) error {
+			path := ctx.Args().First()
+			if path == "" {
+				return cli.Exit("a path to an osv-scanner.toml file is required", 1)
+			}
+
+			var manager config.ConfigManager
+			diagnostics := manager.Validate(path)
+
+			if len(diagnostics) == 0 {
+				fmt.Fprintf(ctx.App.Writer, "%s: no issues found\n", path)
+
+				return nil
+			}
+
+			hasError := false
+			for _, d := range diagnostics {
+				if d.Severity == "error" {
+					hasError = true
+				}
+				if d.Line > 0 {
+					fmt.Fprintf(ctx.App.Writer, "%s:%d: %s: %s\n", path, d.Line, d.Severity, d.Message)
+				} else {
+					fmt.Fprintf(ctx.App.Writer, "%s: %s: %s\n", path, d.Severity, d.Message)
+				}
+			}
+
+			if hasError {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
+
+// configAuditCommand reports exception hygiene for a config file: entries
+// with no expiry, entries expiring soon, entries with no reason, and, using
+// match counts persisted by a prior `osv-scanner` scan (see Config.SaveState
+// and LoadConfigState), entries that haven't matched anything lately.
+//
+// This tree has no scan command or JSON/SARIF reporters to wire
+// --fail-on-stale-config or audit-report emission into (see the top-level
+// TODO in cmd/osv-scanner), so this command is the audit entry point for
+// now: it's what CI should invoke after a scan to gate on hygiene, reading
+// whatever match counts that scan's Config.SaveState calls left behind.
+// --json prints the report as JSON for a CI system to parse directly, as a
+// stand-in for the reporter-native JSON/SARIF emission the request asked
+// for.
+func configAuditCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "audit",
+		Usage:     "report stale or low-hygiene entries in an osv-scanner.toml config file",
+		ArgsUsage: "<path to osv-scanner.toml>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "fail-on-stale-config",
+				Usage: "exit with a non-zero status if any hygiene finding is reported",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "print the audit report as JSON instead of plain text",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			path := ctx.Args().First()
+			if path == "" {
+				return cli.Exit("a path to an osv-scanner.toml file is required", 1)
+			}
+
+			var manager config.ConfigManager
+			if err := manager.UseOverride(path); err != nil {
+				return cli.Exit(fmt.Sprintf("failed to load config: %s", err), 1)
+			}
+
+			state, err := config.LoadConfigState(path)
+			if err != nil {
+				return cli.Exit(fmt.Sprintf("failed to load config state: %s", err), 1)
+			}
+
+			// scannedPackages is nil because this command only has the
+			// config file, not a scan's package universe. AuditScan treats a
+			// nil scannedPackages as "no scan data available" and skips the
+			// "unknown_target" check accordingly; it's exercised by
+			// AuditScan callers that do have that data (i.e. a scan command,
+			// once one exists in this tree).
+			report := manager.OverrideConfig.AuditScan(time.Now(), state.MatchCounts, nil)
+
+			if ctx.Bool("json") {
+				encoded, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return cli.Exit(fmt.Sprintf("failed to marshal audit report: %s", err), 1)
+				}
+				fmt.Fprintln(ctx.App.Writer, string(encoded))
+			} else if len(report.Findings) == 0 {
+				fmt.Fprintf(ctx.App.Writer, "%s: no stale entries found\n", path)
+			} else {
+				for _, finding := range report.Findings {
+					fmt.Fprintf(ctx.App.Writer, "%s: %s: %s: %s\n", path, finding.Kind, finding.Target, finding.Message)
+				}
+			}
+
+			if ctx.Bool("fail-on-stale-config") && report.HasErrors() {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
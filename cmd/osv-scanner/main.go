@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	// TODO: once a scan command lands here, it should call Config.SaveState
+	// after each target and thread config.AuditScan's report through the
+	// JSON/SARIF reporters, with a --fail-on-stale-config flag gating the
+	// exit code. Until then, `osv-scanner config audit` (see config.go) is
+	// the entry point for config hygiene, reading whatever state a prior
+	// scan left behind.
+	app := &cli.App{
+		Name:  "osv-scanner",
+		Usage: "scans a project for known vulnerabilities",
+		Commands: []*cli.Command{
+			ConfigCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
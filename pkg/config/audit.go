@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// staleExpiryWindow is how close to now() an entry's expiry can be before
+// Audit starts flagging it as "expiring soon".
+const staleExpiryWindow = 30 * 24 * time.Hour
+
+type ConfigAuditFindingKind string
+
+const (
+	FindingNoExpiry      ConfigAuditFindingKind = "no_expiry"
+	FindingExpiringSoon  ConfigAuditFindingKind = "expiring_soon"
+	FindingNoReason      ConfigAuditFindingKind = "no_reason"
+	FindingUnused        ConfigAuditFindingKind = "unused"
+	FindingUnknownTarget ConfigAuditFindingKind = "unknown_target"
+)
+
+// ConfigAuditFinding is a single exception-hygiene issue found in a Config.
+type ConfigAuditFinding struct {
+	Kind ConfigAuditFindingKind
+	// Target identifies the offending entry: a vulnerability ID for an
+	// IgnoredVulns entry, or "name@ecosystem" for a PackageOverrides entry.
+	// It's also the key matchCounts is keyed by in AuditScan.
+	Target  string
+	Message string
+}
+
+// ConfigAuditReport is the result of auditing a Config for exception hygiene.
+type ConfigAuditReport struct {
+	GeneratedAt time.Time
+	Findings    []ConfigAuditFinding
+}
+
+// HasErrors reports whether the report contains any finding that should
+// gate CI, for callers implementing a --fail-on-stale-config style flag.
+func (r ConfigAuditReport) HasErrors() bool {
+	return len(r.Findings) > 0
+}
+
+// Audit checks IgnoredVulns and PackageOverrides for exception hygiene:
+// entries with no expiry, entries expiring within staleExpiryWindow of now,
+// and entries with no Reason given. Use AuditScan instead when match counts
+// and the scan's package universe are available, to also flag entries that
+// never matched and entries targeting ecosystems/packages outside the scan.
+func (c *Config) Audit(now time.Time) ConfigAuditReport {
+	report := ConfigAuditReport{GeneratedAt: now}
+
+	for _, entry := range c.IgnoredVulns {
+		report.Findings = append(report.Findings, auditExpiry(entry.ID, entry.IgnoreUntil, now)...)
+		if entry.Reason == "" {
+			report.Findings = append(report.Findings, ConfigAuditFinding{
+				Kind: FindingNoReason, Target: entry.ID, Message: "no reason given",
+			})
+		}
+	}
+
+	for _, entry := range c.PackageOverrides {
+		target := packageOverrideTarget(entry)
+		report.Findings = append(report.Findings, auditExpiry(target, entry.EffectiveUntil, now)...)
+		if entry.Reason == "" {
+			report.Findings = append(report.Findings, ConfigAuditFinding{
+				Kind: FindingNoReason, Target: target, Message: "no reason given",
+			})
+		}
+	}
+
+	return report
+}
+
+// AuditScan extends Audit with findings that need a completed scan's data:
+// entries that never matched anything (matchCounts, typically loaded via
+// LoadConfigState and persisted back with ConfigState.Save after the scan),
+// and PackageOverrides entries whose Name/Ecosystem don't appear anywhere in
+// scannedPackages.
+//
+// scannedPackages being nil is a distinct case from it being non-nil-but-
+// empty: nil means the scan's package universe isn't available to the
+// caller at all (e.g. `config audit` inspecting a config file on its own,
+// with no scan having run), and the unknown-target check is skipped
+// entirely rather than flagging every PackageOverrides entry as unknown. A
+// non-nil, empty slice means a scan ran and genuinely found no packages, so
+// every entry is correctly flagged.
+func (c *Config) AuditScan(now time.Time, matchCounts map[string]int, scannedPackages []models.PackageInfo) ConfigAuditReport {
+	report := c.Audit(now)
+
+	for _, entry := range c.IgnoredVulns {
+		if matchCounts[entry.ID] == 0 {
+			report.Findings = append(report.Findings, ConfigAuditFinding{
+				Kind: FindingUnused, Target: entry.ID, Message: "did not match any vulnerability in the last scan",
+			})
+		}
+	}
+
+	for _, entry := range c.PackageOverrides {
+		target := packageOverrideTarget(entry)
+		if matchCounts[target] == 0 {
+			report.Findings = append(report.Findings, ConfigAuditFinding{
+				Kind: FindingUnused, Target: target, Message: "did not match any package in the last scan",
+			})
+		}
+		if scannedPackages != nil && !packageOverrideInScanUniverse(entry, scannedPackages) {
+			report.Findings = append(report.Findings, ConfigAuditFinding{
+				Kind: FindingUnknownTarget, Target: target, Message: "ecosystem/package not present in the scan universe",
+			})
+		}
+	}
+
+	return report
+}
+
+func packageOverrideTarget(entry PackageOverrideEntry) string {
+	return fmt.Sprintf("%s@%s", entry.Name, entry.Ecosystem)
+}
+
+func packageOverrideInScanUniverse(entry PackageOverrideEntry, scannedPackages []models.PackageInfo) bool {
+	for _, pkg := range scannedPackages {
+		if entry.Ecosystem != "" && entry.Ecosystem != pkg.Ecosystem {
+			continue
+		}
+		if entry.nameMatches(pkg.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func auditExpiry(target string, expiry time.Time, now time.Time) []ConfigAuditFinding {
+	if expiry.IsZero() {
+		return []ConfigAuditFinding{
+			{Kind: FindingNoExpiry, Target: target, Message: "no expiry set; exception never lapses"},
+		}
+	}
+	if expiry.After(now) && expiry.Before(now.Add(staleExpiryWindow)) {
+		return []ConfigAuditFinding{
+			{Kind: FindingExpiringSoon, Target: target, Message: fmt.Sprintf("expires %s", expiry.Format(time.RFC3339))},
+		}
+	}
+
+	return nil
+}
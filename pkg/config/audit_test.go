@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// TestAuditScanSkipsUnknownTargetWithoutScanData ensures a nil
+// scannedPackages (config-file-only auditing, no completed scan) doesn't
+// make every PackageOverrides entry look unknown; that check only applies
+// when a scan's package universe is actually available.
+func TestAuditScanSkipsUnknownTargetWithoutScanData(t *testing.T) {
+	cfg := Config{
+		PackageOverrides: []PackageOverrideEntry{
+			{
+				Name:           "lodash",
+				Ecosystem:      "npm",
+				Reason:         "known false positive",
+				EffectiveUntil: time.Now().Add(24 * time.Hour),
+			},
+		},
+	}
+
+	report := cfg.AuditScan(time.Now(), map[string]int{"lodash@npm": 5}, nil)
+
+	for _, finding := range report.Findings {
+		if finding.Kind == FindingUnknownTarget {
+			t.Errorf("got unexpected %s finding with nil scannedPackages: %+v", FindingUnknownTarget, finding)
+		}
+	}
+}
+
+// TestAuditScanFlagsUnknownTargetWithScanData is the counterpart to the test
+// above: once a real (possibly empty) scan universe is supplied, entries
+// outside it are still flagged.
+func TestAuditScanFlagsUnknownTargetWithScanData(t *testing.T) {
+	cfg := Config{
+		PackageOverrides: []PackageOverrideEntry{
+			{
+				Name:           "lodash",
+				Ecosystem:      "npm",
+				Reason:         "known false positive",
+				EffectiveUntil: time.Now().Add(24 * time.Hour),
+			},
+		},
+	}
+
+	report := cfg.AuditScan(time.Now(), map[string]int{"lodash@npm": 5}, []models.PackageInfo{})
+
+	found := false
+	for _, finding := range report.Findings {
+		if finding.Kind == FindingUnknownTarget && finding.Target == "lodash@npm" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected %s finding for lodash@npm when scannedPackages is empty (not nil), got none", FindingUnknownTarget)
+	}
+}
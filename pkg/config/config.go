@@ -1,10 +1,14 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -21,13 +25,63 @@ type ConfigManager struct {
 	DefaultConfig Config
 	// Cache to store loaded configs
 	ConfigMap map[string]Config
+	// Cache to store the chain of parent config paths discovered for a given directory
+	ChainCache map[string][]string
+	// LayeredConfig is the conf.d layers merged by LoadLayered, without
+	// OSV_SCANNER_CONFIG_* env var overrides applied (Get applies those). When
+	// set, it is used as the base config instead of DefaultConfig.
+	LayeredConfig *Config
 }
 
 type Config struct {
-	IgnoredVulns      []IgnoreEntry          `toml:"IgnoredVulns"`
-	PackageOverrides  []PackageOverrideEntry `toml:"PackageOverrides"`
-	LoadPath          string                 `toml:"LoadPath"`
-	GoVersionOverride string                 `toml:"GoVersionOverride"`
+	IgnoredVulns     []IgnoreEntry          `toml:"IgnoredVulns"`
+	PackageOverrides []PackageOverrideEntry `toml:"PackageOverrides"`
+	LoadPath         string                 `toml:"LoadPath"`
+	// LoadPaths records every config file that contributed to this Config,
+	// for reporter logging: conf.d layers (if any) first, then osv-scanner.toml
+	// files ordered from shallowest to deepest directory.
+	LoadPaths         []string `toml:"-"`
+	GoVersionOverride string   `toml:"GoVersionOverride"`
+	// matches counts, for this Config value's lifetime, how many times each
+	// IgnoredVulns/PackageOverrides entry matched (see recordMatch and
+	// SaveState). It's keyed the same way as ConfigAuditFinding.Target.
+	matches map[string]int `toml:"-"`
+}
+
+// recordMatch tracks that the IgnoredVulns/PackageOverrides entry identified
+// by target matched during this scan, so SaveState can persist it for
+// AuditScan to flag unused entries in a later run.
+func (c *Config) recordMatch(target string) {
+	if c.matches == nil {
+		c.matches = map[string]int{}
+	}
+	c.matches[target]++
+}
+
+// SaveState persists the match counts recorded via ShouldIgnore,
+// ShouldIgnorePackage, and ShouldOverridePackageLicense into the
+// ConfigState already saved at LoadPath+".state" (see LoadConfigState),
+// so a later AuditScan can flag entries that went unused. Call it once a
+// target has been fully scanned against this Config. It's a no-op if
+// LoadPath is unset (e.g. a default config not loaded from a file) or
+// nothing matched.
+func (c *Config) SaveState() error {
+	if c.LoadPath == "" || len(c.matches) == 0 {
+		return nil
+	}
+
+	state, err := LoadConfigState(c.LoadPath)
+	if err != nil {
+		return err
+	}
+
+	for target, count := range c.matches {
+		for i := 0; i < count; i++ {
+			state.RecordMatch(target)
+		}
+	}
+
+	return state.Save(c.LoadPath)
 }
 
 type IgnoreEntry struct {
@@ -37,22 +91,27 @@ type IgnoreEntry struct {
 }
 
 type PackageOverrideEntry struct {
+	// Name may be an exact package name, a glob (e.g. "github.com/foo/*"), or
+	// a regex prefixed with "re:" (e.g. "re:^lodash(\\.\\w+)?$").
 	Name string `toml:"name"`
 	// If the version is empty, the entry applies to all versions.
-	Version        string    `toml:"version"`
-	Ecosystem      string    `toml:"ecosystem"`
-	Group          string    `toml:"group"`
-	Ignore         bool      `toml:"ignore"`
-	License        License   `toml:"license"`
-	EffectiveUntil time.Time `toml:"effectiveUntil"`
-	Reason         string    `toml:"reason"`
+	Version string `toml:"version"`
+	// VersionConstraint is evaluated when Version is empty, e.g. ">=1.2.0, <1.5.0",
+	// so an entry with an empty Version applies to all versions only when
+	// VersionConstraint is also empty.
+	// It is parsed according to the semver ordering of Ecosystem, so Ecosystem
+	// must be set whenever VersionConstraint is used.
+	VersionConstraint string    `toml:"versionConstraint"`
+	Ecosystem         string    `toml:"ecosystem"`
+	Group             string    `toml:"group"`
+	Ignore            bool      `toml:"ignore"`
+	License           License   `toml:"license"`
+	EffectiveUntil    time.Time `toml:"effectiveUntil"`
+	Reason            string    `toml:"reason"`
 }
 
 func (e PackageOverrideEntry) matches(pkg models.PackageVulns) bool {
-	if e.Name != "" && e.Name != pkg.Package.Name {
-		return false
-	}
-	if e.Version != "" && e.Version != pkg.Package.Version {
+	if !e.nameMatches(pkg.Package.Name) {
 		return false
 	}
 	if e.Ecosystem != "" && e.Ecosystem != pkg.Package.Ecosystem {
@@ -62,35 +121,87 @@ func (e PackageOverrideEntry) matches(pkg models.PackageVulns) bool {
 		return false
 	}
 
+	switch {
+	case e.Version != "":
+		if e.Version != pkg.Package.Version {
+			return false
+		}
+	case e.VersionConstraint != "":
+		matched, err := matchesVersionConstraint(e.Ecosystem, pkg.Package.Version, e.VersionConstraint)
+		if err != nil || !matched {
+			return false
+		}
+	}
+
 	return true
 }
 
+// nameMatches reports whether name satisfies e.Name, which may be an exact
+// match, a glob, or (when prefixed with "re:") a regular expression.
+func (e PackageOverrideEntry) nameMatches(name string) bool {
+	if e.Name == "" {
+		return true
+	}
+
+	if pattern, ok := strings.CutPrefix(e.Name, "re:"); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+
+		return re.MatchString(name)
+	}
+
+	matched, err := path.Match(e.Name, name)
+	if err != nil {
+		return false
+	}
+
+	return matched
+}
+
 type License struct {
 	Override []string `toml:"override"`
 }
 
 func (c *Config) ShouldIgnore(vulnID string) (bool, IgnoreEntry) {
-	index := slices.IndexFunc(c.IgnoredVulns, func(e IgnoreEntry) bool { return e.ID == vulnID })
+	index := lastIndexFunc(c.IgnoredVulns, func(e IgnoreEntry) bool { return e.ID == vulnID })
 	if index == -1 {
 		return false, IgnoreEntry{}
 	}
 	ignoredLine := c.IgnoredVulns[index]
+	c.recordMatch(ignoredLine.ID)
 
 	return shouldIgnoreTimestamp(ignoredLine.IgnoreUntil), ignoredLine
 }
 
 func (c *Config) filterPackageVersionEntries(pkg models.PackageVulns, condition func(PackageOverrideEntry) bool) (bool, PackageOverrideEntry) {
-	index := slices.IndexFunc(c.PackageOverrides, func(e PackageOverrideEntry) bool {
+	index := lastIndexFunc(c.PackageOverrides, func(e PackageOverrideEntry) bool {
 		return e.matches(pkg) && condition(e)
 	})
 	if index == -1 {
 		return false, PackageOverrideEntry{}
 	}
 	ignoredLine := c.PackageOverrides[index]
+	c.recordMatch(packageOverrideTarget(ignoredLine))
 
 	return shouldIgnoreTimestamp(ignoredLine.EffectiveUntil), ignoredLine
 }
 
+// lastIndexFunc returns the index of the last element in s satisfying f, or
+// -1 if none do. mergeConfigs appends entries shallowest-directory-first, so
+// scanning from the end finds the deepest (most specific) match for a given
+// ID/target, matching mergeConfigs' documented deeper-wins precedence.
+func lastIndexFunc[S ~[]E, E any](s S, f func(E) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if f(s[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // ShouldIgnorePackage determines if the given package should be ignored based on override entries in the config
 func (c *Config) ShouldIgnorePackage(pkg models.PackageVulns) (bool, PackageOverrideEntry) {
 	return c.filterPackageVersionEntries(pkg, func(e PackageOverrideEntry) bool {
@@ -140,11 +251,23 @@ func shouldIgnoreTimestamp(ignoreUntil time.Time) bool {
 // Sets the override config by reading the config file at configPath.
 // Will return an error if loading the config file fails
 func (c *ConfigManager) UseOverride(configPath string) error {
+	file, err := os.Open(configPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
 	config := Config{}
-	_, err := toml.DecodeFile(configPath, &config)
+	meta, err := toml.NewDecoder(file).Decode(&config)
 	if err != nil {
+		return formatTOMLError(configPath, err)
+	}
+	if err := errUndecodedKeys(configPath, meta); err != nil {
 		return err
 	}
+	if err := validatePackageOverrides(config.PackageOverrides); err != nil {
+		return fmt.Errorf("invalid config file %q: %w", configPath, err)
+	}
 	config.LoadPath = configPath
 	c.OverrideConfig = &config
 
@@ -162,7 +285,7 @@ func (c *ConfigManager) Get(r reporter.Reporter, targetPath string) Config {
 		// TODO: This can happen when target is not a file (e.g. Docker container, git hash...etc.)
 		// Figure out a more robust way to load config from non files
 		// r.PrintErrorf("Can't find config path: %s\n", err)
-		return Config{}
+		return c.baseConfig()
 	}
 
 	config, alreadyExists := c.ConfigMap[configPath]
@@ -170,18 +293,39 @@ func (c *ConfigManager) Get(r reporter.Reporter, targetPath string) Config {
 		return config
 	}
 
-	config, configErr := tryLoadConfig(configPath)
+	// Walk upwards from the target's directory, collecting and merging every
+	// osv-scanner.toml up to the filesystem root, a .git boundary, or $HOME
+	// (this includes the target's own directory, so a local config is never
+	// loaded in isolation from its ancestors).
+	config, configErr := c.tryLoadConfigChain(configPath)
 	if configErr == nil {
-		r.Infof("Loaded filter from: %s\n", config.LoadPath)
+		r.Infof("Loaded filter from: %s\n", strings.Join(config.LoadPaths, ", "))
+		config = mergeConfigs([]Config{c.baseConfig(), config})
 	} else {
-		// If config doesn't exist, use the default config
-		config = c.DefaultConfig
+		// If no config exists anywhere in the chain, fall back to the
+		// conf.d layered config (or DefaultConfig if none was loaded)
+		config = c.baseConfig()
+	}
+
+	if err := applyConfigEnvOverrides(&config); err != nil {
+		r.PrintErrorf("Failed to apply OSV_SCANNER_CONFIG_* overrides: %s\n", err)
 	}
+
 	c.ConfigMap[configPath] = config
 
 	return config
 }
 
+// baseConfig returns the conf.d layered config produced by LoadLayered, or
+// DefaultConfig if LoadLayered has not been called.
+func (c *ConfigManager) baseConfig() Config {
+	if c.LayeredConfig != nil {
+		return *c.LayeredConfig
+	}
+
+	return c.DefaultConfig
+}
+
 // Finds the containing folder of `target`, then appends osvScannerConfigName
 func normalizeConfigLoadPath(target string) (string, error) {
 	stat, err := os.Stat(target)
@@ -208,14 +352,144 @@ func tryLoadConfig(configPath string) (Config, error) {
 	if err == nil { // File exists, and we have permission to read
 		defer file.Close()
 
-		_, err := toml.NewDecoder(file).Decode(&config)
+		meta, err := toml.NewDecoder(file).Decode(&config)
 		if err != nil {
-			return Config{}, fmt.Errorf("failed to parse config file: %w", err)
+			return Config{}, formatTOMLError(configPath, err)
+		}
+		if err := errUndecodedKeys(configPath, meta); err != nil {
+			return Config{}, err
+		}
+		if err := validatePackageOverrides(config.PackageOverrides); err != nil {
+			return Config{}, fmt.Errorf("invalid config file %q: %w", configPath, err)
 		}
 		config.LoadPath = configPath
+		config.LoadPaths = []string{configPath}
 
 		return config, nil
 	}
 
 	return Config{}, fmt.Errorf("no config file found on this path: %s", configPath)
 }
+
+// formatTOMLError wraps a toml decode error with the offending file path. If
+// err is a *toml.ParseError (e.g. invalid syntax or a malformed value), its
+// line-number detail is preserved in the message.
+func formatTOMLError(configPath string, err error) error {
+	var parseErr *toml.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("failed to parse config file %q: %s", configPath, parseErr.ErrorWithUsage())
+	}
+
+	return fmt.Errorf("failed to parse config file %q: %w", configPath, err)
+}
+
+// errUndecodedKeys returns an error naming every key in a decoded TOML file
+// that doesn't correspond to a Config field, e.g. "IgnoreVulns" (missing the
+// "d") instead of "IgnoredVulns". toml.Decoder ignores these silently by
+// default, which is how that kind of typo turns into a silent no-op.
+func errUndecodedKeys(configPath string, meta toml.MetaData) error {
+	undecoded := meta.Undecoded()
+	if len(undecoded) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(undecoded))
+	for i, key := range undecoded {
+		keys[i] = key.String()
+	}
+
+	return fmt.Errorf("failed to parse config file %q: unrecognized key(s): %s", configPath, strings.Join(keys, ", "))
+}
+
+// tryLoadConfigChain walks upward from the directory containing `configPath`,
+// collecting and merging every osv-scanner.toml it finds, stopping at the
+// filesystem root, a .git boundary, or $HOME. The walked chain of paths is
+// cached per starting directory so repeated scans don't re-walk the filesystem.
+func (c *ConfigManager) tryLoadConfigChain(configPath string) (Config, error) {
+	containingFolder := filepath.Dir(configPath)
+
+	chain, alreadyWalked := c.ChainCache[containingFolder]
+	if !alreadyWalked {
+		chain = walkConfigChain(containingFolder)
+		if c.ChainCache == nil {
+			c.ChainCache = map[string][]string{}
+		}
+		c.ChainCache[containingFolder] = chain
+	}
+
+	if len(chain) == 0 {
+		return Config{}, fmt.Errorf("no config file found in any parent directory of: %s", containingFolder)
+	}
+
+	configs := make([]Config, 0, len(chain))
+	for _, path := range chain {
+		config, err := tryLoadConfig(path)
+		if err != nil {
+			return Config{}, err
+		}
+		configs = append(configs, config)
+	}
+
+	return mergeConfigs(configs), nil
+}
+
+// walkConfigChain walks upward from dir, collecting the path of every
+// osv-scanner.toml found along the way. It stops (inclusive of that
+// directory) once it reaches a directory containing a .git folder, the
+// user's home directory, or the filesystem root. The returned chain is
+// ordered from shallowest to deepest directory.
+func walkConfigChain(dir string) []string {
+	home, _ := os.UserHomeDir()
+
+	var chain []string
+	for {
+		candidate := filepath.Join(dir, osvScannerConfigName)
+		if _, err := os.Stat(candidate); err == nil {
+			chain = append(chain, candidate)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		if home != "" && dir == home {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	slices.Reverse(chain)
+
+	return chain
+}
+
+// mergeConfigs merges a chain of Configs ordered from shallowest to deepest
+// directory, with deeper-directory entries taking precedence: IgnoredVulns
+// and PackageOverrides are appended together, and scalar fields are
+// last-writer-wins.
+func mergeConfigs(configs []Config) Config {
+	var merged Config
+	for _, config := range configs {
+		merged.IgnoredVulns = append(merged.IgnoredVulns, config.IgnoredVulns...)
+		merged.PackageOverrides = append(merged.PackageOverrides, config.PackageOverrides...)
+		switch {
+		case len(config.LoadPaths) > 0:
+			merged.LoadPaths = append(merged.LoadPaths, config.LoadPaths...)
+		case config.LoadPath != "":
+			merged.LoadPaths = append(merged.LoadPaths, config.LoadPath)
+		}
+		if config.GoVersionOverride != "" {
+			merged.GoVersionOverride = config.GoVersionOverride
+		}
+	}
+
+	if len(merged.LoadPaths) > 0 {
+		merged.LoadPath = merged.LoadPaths[len(merged.LoadPaths)-1]
+	}
+
+	return merged
+}
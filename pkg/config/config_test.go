@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/reporter"
+)
+
+// silentReporter discards everything Get logs; the tests only care about
+// the merged Config it returns.
+type silentReporter struct{ reporter.Reporter }
+
+func (silentReporter) Infof(string, ...interface{})       {}
+func (silentReporter) PrintErrorf(string, ...interface{}) {}
+
+func writeConfigFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// TestShouldIgnoreDeepDirectoryWins reproduces a root config that ignores a
+// vulnerability forever and a subdirectory config that re-enables it: the
+// subdirectory's (deeper, more specific) entry must win, per mergeConfigs'
+// documented "deeper-directory entries take precedence" semantics.
+func TestShouldIgnoreDeepDirectoryWins(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeConfigFile(t, filepath.Join(root, osvScannerConfigName), `
+[[IgnoredVulns]]
+id = "CVE-SHARED"
+`)
+	writeConfigFile(t, filepath.Join(sub, osvScannerConfigName), `
+[[IgnoredVulns]]
+id = "CVE-SHARED"
+ignoreUntil = 2020-01-01T00:00:00Z
+`)
+	target := filepath.Join(sub, "package.json")
+	writeConfigFile(t, target, "{}")
+
+	manager := ConfigManager{ConfigMap: map[string]Config{}}
+	cfg := manager.Get(silentReporter{}, target)
+
+	if shouldIgnore, _ := cfg.ShouldIgnore("CVE-SHARED"); shouldIgnore {
+		t.Errorf("ShouldIgnore(CVE-SHARED) = true, want false: the subdirectory's expired ignoreUntil should take precedence over the root's")
+	}
+}
+
+// TestGetMergesAncestorConfigsWithLocal reproduces a root config and a
+// subdirectory config that each ignore a different vulnerability: scanning a
+// target under the subdirectory must see both, not just the local one.
+func TestGetMergesAncestorConfigsWithLocal(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeConfigFile(t, filepath.Join(root, osvScannerConfigName), `
+[[IgnoredVulns]]
+id = "CVE-PARENT"
+`)
+	writeConfigFile(t, filepath.Join(sub, osvScannerConfigName), `
+[[IgnoredVulns]]
+id = "CVE-LOCAL"
+`)
+	target := filepath.Join(sub, "package.json")
+	writeConfigFile(t, target, "{}")
+
+	manager := ConfigManager{ConfigMap: map[string]Config{}}
+	cfg := manager.Get(silentReporter{}, target)
+
+	if shouldIgnore, _ := cfg.ShouldIgnore("CVE-PARENT"); !shouldIgnore {
+		t.Errorf("ShouldIgnore(CVE-PARENT) = false, want true: the ancestor config's entry was dropped")
+	}
+	if shouldIgnore, _ := cfg.ShouldIgnore("CVE-LOCAL"); !shouldIgnore {
+		t.Errorf("ShouldIgnore(CVE-LOCAL) = false, want true: the local config's entry was dropped")
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// configEnvPrefix is the prefix for environment variables that override
+// individual Config fields, e.g. OSV_SCANNER_CONFIG_IGNORED_VULNS.
+const configEnvPrefix = "OSV_SCANNER_CONFIG_"
+
+// LoadLayered reads every *.toml file in each given conf.d-style directory
+// (e.g. /etc/osv-scanner/conf.d or $XDG_CONFIG_HOME/osv-scanner/conf.d), in
+// lexical order, and merges them into a base Config using the same
+// deeper-wins semantics as the parent-directory chain (files later in
+// lexical order take precedence). Directories that don't exist are silently
+// skipped, so callers can pass every conventional location unconditionally.
+//
+// OSV_SCANNER_CONFIG_* environment variables are then layered on top, so
+// individual fields can be overridden or appended to without editing files
+// on disk. The merged result is cached on the ConfigManager and used by Get
+// as the base config for every subsequent lookup, beneath any per-target
+// osv-scanner.toml but above DefaultConfig.
+func (c *ConfigManager) LoadLayered(paths ...string) (Config, error) {
+	var layers []Config
+
+	for _, dir := range paths {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return Config{}, fmt.Errorf("failed to read conf.d directory %q: %w", dir, err)
+		}
+
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".toml" {
+				continue
+			}
+			names = append(names, entry.Name())
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			layerPath := filepath.Join(dir, name)
+
+			layer, err := tryLoadConfig(layerPath)
+			if err != nil {
+				return Config{}, fmt.Errorf("failed to load conf.d layer %q: %w", layerPath, err)
+			}
+			layers = append(layers, layer)
+		}
+	}
+
+	merged := mergeConfigs(layers)
+
+	// c.LayeredConfig is deliberately stored without env overrides applied:
+	// Get applies them itself (see applyConfigEnvOverrides call in Get), and
+	// doing it here too would double the effect of every override, e.g.
+	// duplicating every entry appended to IgnoredVulns. The value returned to
+	// direct callers of LoadLayered still needs to reflect the overrides, so
+	// it's applied to a separate copy just before returning.
+	c.LayeredConfig = &merged
+
+	withEnvOverrides := merged
+	if err := applyConfigEnvOverrides(&withEnvOverrides); err != nil {
+		return Config{}, err
+	}
+
+	return withEnvOverrides, nil
+}
+
+// applyConfigEnvOverrides layers OSV_SCANNER_CONFIG_* environment variables
+// on top of config. Recognised variables:
+//
+//	OSV_SCANNER_CONFIG_IGNORED_VULNS        comma-separated vulnerability IDs, appended to IgnoredVulns
+//	OSV_SCANNER_CONFIG_GO_VERSION_OVERRIDE   overrides GoVersionOverride
+//
+// Each variable also has a "_FILE" counterpart (e.g.
+// OSV_SCANNER_CONFIG_IGNORED_VULNS_FILE) that reads the value from the
+// referenced file instead, so secrets or long ignore lists can be injected
+// from mounted files in CI/container environments.
+func applyConfigEnvOverrides(config *Config) error {
+	ignoredVulns, err := lookupConfigEnv("IGNORED_VULNS")
+	if err != nil {
+		return err
+	}
+	for _, id := range strings.Split(ignoredVulns, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		config.IgnoredVulns = append(config.IgnoredVulns, IgnoreEntry{ID: id})
+	}
+
+	goVersionOverride, err := lookupConfigEnv("GO_VERSION_OVERRIDE")
+	if err != nil {
+		return err
+	}
+	if goVersionOverride != "" {
+		config.GoVersionOverride = goVersionOverride
+	}
+
+	return nil
+}
+
+// lookupConfigEnv reads OSV_SCANNER_CONFIG_<name>, preferring the
+// OSV_SCANNER_CONFIG_<name>_FILE variant when it's set.
+func lookupConfigEnv(name string) (string, error) {
+	fileVar := configEnvPrefix + name + "_FILE"
+	if path, ok := os.LookupEnv(fileVar); ok && path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", fileVar, err)
+		}
+
+		return strings.TrimSpace(string(contents)), nil
+	}
+
+	return os.Getenv(configEnvPrefix + name), nil
+}
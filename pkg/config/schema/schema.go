@@ -0,0 +1,10 @@
+// Package schema embeds the generated JSON schema for pkg/config.Config, for
+// editor tooling and external validation of osv-scanner.toml files.
+package schema
+
+import _ "embed"
+
+//go:generate go run github.com/invopop/jsonschema/cmd/jsonschema-gen -type Config -out config.schema.json ../
+
+//go:embed config.schema.json
+var Config []byte
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// configStateSuffix names the sibling file ConfigState is persisted to,
+// alongside a loaded osv-scanner.toml.
+const configStateSuffix = ".state"
+
+// ConfigState tracks how many times each IgnoredVulns/PackageOverrides entry
+// has matched across scans, so AuditScan can flag entries that have gone
+// unused. It's persisted next to its Config at "<LoadPath>.state".
+type ConfigState struct {
+	// MatchCounts is keyed the same way as ConfigAuditFinding.Target: a
+	// vulnerability ID for IgnoredVulns entries, or "name@ecosystem" for
+	// PackageOverrides entries.
+	MatchCounts map[string]int `json:"matchCounts"`
+}
+
+// LoadConfigState reads the persisted match counters for the config loaded
+// from configLoadPath, returning a zero-valued ConfigState if none has been
+// saved yet.
+func LoadConfigState(configLoadPath string) (ConfigState, error) {
+	data, err := os.ReadFile(configLoadPath + configStateSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ConfigState{MatchCounts: map[string]int{}}, nil
+		}
+
+		return ConfigState{}, fmt.Errorf("failed to read config state: %w", err)
+	}
+
+	var state ConfigState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return ConfigState{}, fmt.Errorf("failed to parse config state: %w", err)
+	}
+	if state.MatchCounts == nil {
+		state.MatchCounts = map[string]int{}
+	}
+
+	return state, nil
+}
+
+// RecordMatch increments the match counter for target. Call it once for
+// every time an IgnoredVulns/PackageOverrides entry matched.
+func (s *ConfigState) RecordMatch(target string) {
+	if s.MatchCounts == nil {
+		s.MatchCounts = map[string]int{}
+	}
+	s.MatchCounts[target]++
+}
+
+// Save persists the match counters for the config loaded from configLoadPath.
+func (s ConfigState) Save(configLoadPath string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config state: %w", err)
+	}
+
+	if err := os.WriteFile(configLoadPath+configStateSuffix, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config state: %w", err)
+	}
+
+	return nil
+}
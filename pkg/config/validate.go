@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// knownEcosystems lists the OSV ecosystem names Validate recognises;
+// anything else is flagged as a warning rather than rejected outright, since
+// new ecosystems are added to the OSV schema over time.
+var knownEcosystems = []string{
+	"npm", "PyPI", "Go", "Maven", "crates.io", "Packagist", "RubyGems",
+	"NuGet", "Linux", "Debian", "Alpine", "Hex", "Pub", "ConanCenter",
+	"CRAN", "SwiftURL",
+}
+
+// ConfigDiagnostic describes a single issue found by ConfigManager.Validate.
+type ConfigDiagnostic struct {
+	// Severity is "error" for issues that make the config invalid or
+	// unusable, and "warning" for hygiene issues that don't block loading.
+	Severity string
+	Message  string
+	// Line is the 1-indexed line the issue was found on, or 0 if unknown.
+	Line int
+}
+
+// Validate loads the config file at path in strict mode (unknown keys are
+// rejected) and returns diagnostics for anything it finds: unknown keys,
+// malformed values, unrecognised ecosystems, and IgnoredVulns/PackageOverrides
+// entries whose effectiveUntil/ignoreUntil has already passed. A nil slice
+// means the file is clean.
+func (c *ConfigManager) Validate(path string) []ConfigDiagnostic {
+	file, err := os.Open(path)
+	if err != nil {
+		return []ConfigDiagnostic{{Severity: "error", Message: fmt.Sprintf("failed to open config file: %s", err)}}
+	}
+	defer file.Close()
+
+	var diagnostics []ConfigDiagnostic
+
+	var config Config
+	meta, err := toml.NewDecoder(file).Decode(&config)
+	if err != nil {
+		diagnostics = append(diagnostics, diagnosticFromTOMLError(err))
+	}
+	for _, key := range meta.Undecoded() {
+		diagnostics = append(diagnostics, ConfigDiagnostic{
+			Severity: "error",
+			Message:  fmt.Sprintf("unrecognized key: %s", key),
+		})
+	}
+
+	now := time.Now()
+
+	for i, entry := range config.IgnoredVulns {
+		if entry.Reason == "" {
+			diagnostics = append(diagnostics, ConfigDiagnostic{
+				Severity: "warning",
+				Message:  fmt.Sprintf("IgnoredVulns[%d] (%s): no reason given", i, entry.ID),
+			})
+		}
+		if !entry.IgnoreUntil.IsZero() && entry.IgnoreUntil.Before(now) {
+			diagnostics = append(diagnostics, ConfigDiagnostic{
+				Severity: "warning",
+				Message:  fmt.Sprintf("IgnoredVulns[%d] (%s): ignoreUntil %s is in the past", i, entry.ID, entry.IgnoreUntil.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	for i, entry := range config.PackageOverrides {
+		if entry.Ecosystem != "" && !slices.Contains(knownEcosystems, entry.Ecosystem) {
+			diagnostics = append(diagnostics, ConfigDiagnostic{
+				Severity: "warning",
+				Message:  fmt.Sprintf("PackageOverrides[%d] (%s): unrecognised ecosystem %q", i, entry.Name, entry.Ecosystem),
+			})
+		}
+		if entry.VersionConstraint != "" {
+			if err := validateVersionConstraint(entry.Ecosystem, entry.VersionConstraint); err != nil {
+				diagnostics = append(diagnostics, ConfigDiagnostic{
+					Severity: "error",
+					Message:  fmt.Sprintf("PackageOverrides[%d] (%s): %s", i, entry.Name, err),
+				})
+			}
+		}
+		if entry.Reason == "" {
+			diagnostics = append(diagnostics, ConfigDiagnostic{
+				Severity: "warning",
+				Message:  fmt.Sprintf("PackageOverrides[%d] (%s): no reason given", i, entry.Name),
+			})
+		}
+		if !entry.EffectiveUntil.IsZero() && entry.EffectiveUntil.Before(now) {
+			diagnostics = append(diagnostics, ConfigDiagnostic{
+				Severity: "warning",
+				Message:  fmt.Sprintf("PackageOverrides[%d] (%s): effectiveUntil %s is in the past", i, entry.Name, entry.EffectiveUntil.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// diagnosticFromTOMLError converts a toml decode error into a ConfigDiagnostic,
+// preserving the line number when err is a *toml.ParseError.
+func diagnosticFromTOMLError(err error) ConfigDiagnostic {
+	var parseErr *toml.ParseError
+	if errors.As(err, &parseErr) {
+		return ConfigDiagnostic{Severity: "error", Message: parseErr.ErrorWithUsage(), Line: parseErr.Line}
+	}
+
+	return ConfigDiagnostic{Severity: "error", Message: err.Error()}
+}
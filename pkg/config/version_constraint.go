@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/semantic"
+)
+
+// constraintOperators are checked longest-prefix-first so that e.g. ">="
+// isn't mistaken for ">".
+var constraintOperators = []string{">=", "<=", "==", "!=", ">", "<", "="}
+
+// matchesVersionConstraint reports whether version satisfies every
+// comma-separated clause in constraint (e.g. ">=1.2.0, <1.5.0"), comparing
+// versions using the semver ordering for ecosystem.
+func matchesVersionConstraint(ecosystem, version, constraint string) (bool, error) {
+	parsedVersion, err := semantic.Parse(version, ecosystem)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse version %q for ecosystem %q: %w", version, ecosystem, err)
+	}
+
+	clauses, err := parseVersionConstraint(ecosystem, constraint)
+	if err != nil {
+		return false, err
+	}
+
+	for _, clause := range clauses {
+		if !clause.satisfiedBy(parsedVersion) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// validateVersionConstraint reports an error if constraint cannot be parsed
+// for ecosystem, without needing a package version to compare against. It is
+// used to surface config-load errors for malformed VersionConstraint fields.
+func validateVersionConstraint(ecosystem, constraint string) error {
+	_, err := parseVersionConstraint(ecosystem, constraint)
+
+	return err
+}
+
+type constraintClause struct {
+	operator string
+	version  semantic.Version
+}
+
+func (c constraintClause) satisfiedBy(version semantic.Version) bool {
+	cmp := version.Compare(c.version)
+
+	switch c.operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "==", "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	default:
+		return false
+	}
+}
+
+func parseVersionConstraint(ecosystem, constraint string) ([]constraintClause, error) {
+	clauseStrs := strings.Split(constraint, ",")
+	clauses := make([]constraintClause, 0, len(clauseStrs))
+
+	for _, clauseStr := range clauseStrs {
+		clauseStr = strings.TrimSpace(clauseStr)
+		if clauseStr == "" {
+			continue
+		}
+
+		operator, rawVersion, err := splitConstraintClause(clauseStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+
+		version, err := semantic.Parse(rawVersion, ecosystem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q for ecosystem %q: %w", constraint, ecosystem, err)
+		}
+
+		clauses = append(clauses, constraintClause{operator: operator, version: version})
+	}
+
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("version constraint %q has no clauses", constraint)
+	}
+
+	return clauses, nil
+}
+
+func splitConstraintClause(clause string) (operator, version string, err error) {
+	for _, op := range constraintOperators {
+		if after, ok := strings.CutPrefix(clause, op); ok {
+			return op, strings.TrimSpace(after), nil
+		}
+	}
+
+	return "", "", fmt.Errorf("clause %q has no recognised comparison operator", clause)
+}
+
+// validatePackageOverrides returns a descriptive error if any entry's
+// VersionConstraint can't be parsed for its declared Ecosystem.
+func validatePackageOverrides(entries []PackageOverrideEntry) error {
+	for _, entry := range entries {
+		if entry.VersionConstraint == "" {
+			continue
+		}
+
+		if err := validateVersionConstraint(entry.Ecosystem, entry.VersionConstraint); err != nil {
+			return fmt.Errorf("package override %q: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
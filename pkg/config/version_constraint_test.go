@@ -0,0 +1,98 @@
+package config
+
+import "testing"
+
+func TestMatchesVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		ecosystem  string
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"npm within range", "npm", "1.3.0", ">=1.2.0, <1.5.0", true},
+		{"npm below range", "npm", "1.1.0", ">=1.2.0, <1.5.0", false},
+		{"npm at exclusive upper bound", "npm", "1.5.0", ">=1.2.0, <1.5.0", false},
+		{"npm at inclusive lower bound", "npm", "1.2.0", ">=1.2.0, <1.5.0", true},
+
+		{"PyPI within range", "PyPI", "2.0.1", ">=2.0.0, <3.0.0", true},
+		{"PyPI at range boundary", "PyPI", "3.0.0", ">=2.0.0, <3.0.0", false},
+		{"PyPI below range", "PyPI", "1.9.9", ">=2.0.0, <3.0.0", false},
+
+		{"Go within range", "Go", "1.21.0", ">=1.20.0, <1.22.0", true},
+		{"Go below range", "Go", "1.19.0", ">=1.20.0, <1.22.0", false},
+		{"Go above range", "Go", "1.22.0", ">=1.20.0, <1.22.0", false},
+
+		{"Maven within range", "Maven", "4.0.1", ">=4.0.0, <5.0.0", true},
+		{"Maven above range", "Maven", "5.0.0", ">=4.0.0, <5.0.0", false},
+
+		{"single equality clause matches", "npm", "1.2.0", "==1.2.0", true},
+		{"single equality clause mismatches", "npm", "1.2.1", "==1.2.0", false},
+		{"single not-equal clause", "npm", "1.2.0", "!=1.2.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchesVersionConstraint(tt.ecosystem, tt.version, tt.constraint)
+			if err != nil {
+				t.Fatalf("matchesVersionConstraint(%q, %q, %q) returned error: %v", tt.ecosystem, tt.version, tt.constraint, err)
+			}
+			if got != tt.want {
+				t.Errorf("matchesVersionConstraint(%q, %q, %q) = %v, want %v", tt.ecosystem, tt.version, tt.constraint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		ecosystem  string
+		constraint string
+		wantErr    bool
+	}{
+		{"npm valid range", "npm", ">=1.2.0, <1.5.0", false},
+		{"PyPI valid range", "PyPI", ">=2.0.0, <3.0.0", false},
+		{"Go valid range", "Go", ">=1.20.0, <1.22.0", false},
+		{"Maven valid range", "Maven", ">=4.0.0, <5.0.0", false},
+		{"missing operator", "npm", "1.2.0", true},
+		{"empty clause", "npm", "", true},
+		{"unparsable version for ecosystem", "npm", ">=not-a-version", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVersionConstraint(tt.ecosystem, tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVersionConstraint(%q, %q) error = %v, wantErr %v", tt.ecosystem, tt.constraint, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPackageOverrideEntryNameMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		input   string
+		want    bool
+	}{
+		{"empty pattern matches everything", "", "anything", true},
+		{"exact match", "lodash", "lodash", true},
+		{"exact mismatch", "lodash", "underscore", false},
+		{"glob match", "github.com/foo/*", "github.com/foo/bar", true},
+		{"glob mismatch different owner", "github.com/foo/*", "github.com/baz/bar", false},
+		{"regex match", `re:^lodash(\.\w+)?$`, "lodash.merge", true},
+		{"regex mismatch", `re:^lodash(\.\w+)?$`, "underscore", false},
+		{"invalid regex never matches", `re:(`, "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := PackageOverrideEntry{Name: tt.pattern}
+			if got := entry.nameMatches(tt.input); got != tt.want {
+				t.Errorf("nameMatches(%q) with pattern %q = %v, want %v", tt.input, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}